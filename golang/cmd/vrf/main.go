@@ -0,0 +1,342 @@
+// Command vrf is a small CLI around the vrf.Suite backends: it generates
+// keypairs, proves and verifies individual VRF evaluations, and batch
+// verifies a directory of proofs produced by the prove/keygen subcommands.
+//
+// Keys are kept one-per-file under a keystore directory (see the keystore
+// package); a single proof can be written as hex text or as raw bytes for
+// piping into other tools, or with prove -batchdir/-id as a keystore-managed
+// PEM file, which is the format batch-verify expects one of per entry so
+// large proof sets can be streamed rather than loaded as a single blob.
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ursa-mikail/verifiable_random_functions/core/crypto/vrf"
+	"github.com/ursa-mikail/verifiable_random_functions/core/crypto/vrf/ed25519"
+	"github.com/ursa-mikail/verifiable_random_functions/core/crypto/vrf/p256"
+	"github.com/ursa-mikail/verifiable_random_functions/keystore"
+)
+
+var suites = map[string]vrf.Suite{
+	"p256":    p256.Suite{},
+	"ed25519": ed25519.Suite{},
+}
+
+var keyCoders = map[string]vrf.KeyCoder{
+	"p256":    p256.Suite{},
+	"ed25519": ed25519.Suite{},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "keygen":
+		err = runKeygen(os.Args[2:])
+	case "prove":
+		err = runProve(os.Args[2:])
+	case "verify":
+		err = runVerify(os.Args[2:])
+	case "batch-verify":
+		err = runBatchVerify(os.Args[2:])
+	case "sortition":
+		err = runSortition(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "vrf:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: vrf <keygen|prove|verify|batch-verify|sortition> [flags]")
+}
+
+func lookupSuite(name string) (vrf.Suite, error) {
+	suite, ok := suites[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown suite %q", name)
+	}
+	return suite, nil
+}
+
+func lookupKeyCoder(name string) (vrf.KeyCoder, error) {
+	kc, ok := keyCoders[name]
+	if !ok {
+		return nil, fmt.Errorf("suite %q does not support persisting keys", name)
+	}
+	return kc, nil
+}
+
+func runKeygen(args []string) error {
+	fs := flag.NewFlagSet("keygen", flag.ExitOnError)
+	suiteName := fs.String("suite", "p256", "VRF suite to use: p256 or ed25519")
+	dir := fs.String("dir", "./keys", "keystore directory to write key.priv and key.pub to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	suite, err := lookupSuite(*suiteName)
+	if err != nil {
+		return err
+	}
+	kc, err := lookupKeyCoder(*suiteName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*dir, 0700); err != nil {
+		return err
+	}
+
+	sk, pk := suite.GenerateKey()
+	if err := keystore.SavePrivateKey(*dir, kc, sk); err != nil {
+		return fmt.Errorf("saving private key: %w", err)
+	}
+	if err := keystore.SavePublicKey(*dir, kc, pk); err != nil {
+		return fmt.Errorf("saving public key: %w", err)
+	}
+
+	fmt.Printf("wrote %s and %s to %s\n", keystore.PrivateKeyFile, keystore.PublicKeyFile, *dir)
+	return nil
+}
+
+func runProve(args []string) error {
+	fs := flag.NewFlagSet("prove", flag.ExitOnError)
+	suiteName := fs.String("suite", "p256", "VRF suite to use: p256 or ed25519")
+	dir := fs.String("dir", "./keys", "keystore directory holding key.priv")
+	msg := fs.String("msg", "", "message (alpha) to evaluate the VRF on")
+	out := fs.String("out", "", "file to write the proof to (default: stdout)")
+	encoding := fs.String("encoding", "hex", "proof encoding to write: hex or raw")
+	batchDir := fs.String("batchdir", "", "write the proof as a keystore-managed file under this directory instead of -out, for later batch-verify")
+	id := fs.String("id", "", "proof id to write under -batchdir (required with -batchdir)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	suite, err := lookupSuite(*suiteName)
+	if err != nil {
+		return err
+	}
+	kc, err := lookupKeyCoder(*suiteName)
+	if err != nil {
+		return err
+	}
+
+	sk, err := keystore.LoadPrivateKey(*dir, kc)
+	if err != nil {
+		return fmt.Errorf("loading private key: %w", err)
+	}
+
+	index, proof := sk.Evaluate([]byte(*msg))
+
+	if *batchDir != "" {
+		if *id == "" {
+			return fmt.Errorf("-id is required with -batchdir")
+		}
+		if err := os.MkdirAll(*batchDir, 0700); err != nil {
+			return err
+		}
+		if err := keystore.SaveProof(*batchDir, *id, suite, proof); err != nil {
+			return fmt.Errorf("saving proof: %w", err)
+		}
+		fmt.Printf("wrote %s\n", keystore.ProofPath(*batchDir, *id))
+		fmt.Fprintf(os.Stderr, "manifest line: %s %x %x\n", *id, []byte(*msg), index)
+		return nil
+	}
+
+	encoded, err := encodeProof(*encoding, proof)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		os.Stdout.Write(encoded)
+		if *encoding == "hex" {
+			fmt.Println()
+		}
+	} else if err := os.WriteFile(*out, encoded, 0644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "index: %x\n", index)
+	return nil
+}
+
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	suiteName := fs.String("suite", "p256", "VRF suite to use: p256 or ed25519")
+	dir := fs.String("dir", "./keys", "keystore directory holding key.pub")
+	msg := fs.String("msg", "", "message (alpha) the proof was computed over")
+	proofFile := fs.String("proof", "", "file containing the proof to verify")
+	encoding := fs.String("encoding", "hex", "proof encoding to read: hex or raw")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	kc, err := lookupKeyCoder(*suiteName)
+	if err != nil {
+		return err
+	}
+
+	pk, err := keystore.LoadPublicKey(*dir, kc)
+	if err != nil {
+		return fmt.Errorf("loading public key: %w", err)
+	}
+
+	raw, err := os.ReadFile(*proofFile)
+	if err != nil {
+		return err
+	}
+	proof, err := decodeProof(*encoding, raw)
+	if err != nil {
+		return err
+	}
+
+	index, err := pk.ProofToHash([]byte(*msg), proof)
+	if err != nil {
+		return fmt.Errorf("proof does not verify: %w", err)
+	}
+
+	fmt.Printf("OK index: %x\n", index)
+	return nil
+}
+
+// batchEntry is one line of a batch-verify manifest: an id identifying the
+// proof file to load (via keystore.LoadProof), the message it was computed
+// over, and the VRF output it's expected to produce.
+type batchEntry struct {
+	id            string
+	msg           []byte
+	expectedIndex [32]byte
+}
+
+// readManifest parses whitespace-separated "id hex(msg) hex(index)" lines.
+func readManifest(path string) ([]batchEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []batchEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("manifest: malformed line %q", line)
+		}
+		msg, err := hex.DecodeString(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("manifest: bad message hex in %q: %w", line, err)
+		}
+		indexBytes, err := hex.DecodeString(fields[2])
+		if err != nil || len(indexBytes) != 32 {
+			return nil, fmt.Errorf("manifest: bad index hex in %q", line)
+		}
+		var entry batchEntry
+		entry.id = fields[0]
+		entry.msg = msg
+		copy(entry.expectedIndex[:], indexBytes)
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func runBatchVerify(args []string) error {
+	fs := flag.NewFlagSet("batch-verify", flag.ExitOnError)
+	suiteName := fs.String("suite", "p256", "VRF suite to use (must support batch verification)")
+	dir := fs.String("dir", "./keys", "keystore directory holding key.pub")
+	proofDir := fs.String("proofdir", "./proofs", "directory of one PEM-encoded proof file per manifest entry")
+	manifestPath := fs.String("manifest", "", "manifest file listing \"id hex(msg) hex(expected-index)\" per line")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	suite, err := lookupSuite(*suiteName)
+	if err != nil {
+		return err
+	}
+	kc, err := lookupKeyCoder(*suiteName)
+	if err != nil {
+		return err
+	}
+	factory, ok := suite.(vrf.BatchVerifierFactory)
+	if !ok {
+		return fmt.Errorf("suite %q does not support batch verification", *suiteName)
+	}
+	batcher := factory.NewBatchVerifier()
+
+	pk, err := keystore.LoadPublicKey(*dir, kc)
+	if err != nil {
+		return fmt.Errorf("loading public key: %w", err)
+	}
+
+	entries, err := readManifest(*manifestPath)
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+
+	for _, e := range entries {
+		proof, err := keystore.LoadProof(*proofDir, e.id, suite)
+		if err != nil {
+			return fmt.Errorf("loading proof %q: %w", e.id, err)
+		}
+		batcher.Add(pk, e.msg, proof, e.expectedIndex)
+	}
+
+	ok, bad := batcher.Verify()
+	if !ok {
+		for _, i := range bad {
+			fmt.Fprintf(os.Stderr, "FAIL %s\n", entries[i].id)
+		}
+		return fmt.Errorf("%d of %d proofs failed to verify", len(bad), len(entries))
+	}
+
+	fmt.Printf("OK: %d proofs verified\n", len(entries))
+	return nil
+}
+
+func encodeProof(encoding string, proof []byte) ([]byte, error) {
+	switch encoding {
+	case "hex":
+		dst := make([]byte, hex.EncodedLen(len(proof)))
+		hex.Encode(dst, proof)
+		return dst, nil
+	case "raw":
+		return proof, nil
+	default:
+		return nil, fmt.Errorf("unknown proof encoding %q (want hex or raw)", encoding)
+	}
+}
+
+func decodeProof(encoding string, data []byte) ([]byte, error) {
+	switch encoding {
+	case "hex":
+		return hex.DecodeString(strings.TrimSpace(string(data)))
+	case "raw":
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unknown proof encoding %q (want hex or raw)", encoding)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ursa-mikail/verifiable_random_functions/keystore"
+)
+
+// TestProveBatchdirWritesKeystoreManagedProof exercises the workflow
+// batch-verify expects: keygen, then prove -batchdir/-id to produce a
+// keystore.LoadProof-compatible file, then batch-verify reading it back.
+func TestProveBatchdirWritesKeystoreManagedProof(t *testing.T) {
+	dir := t.TempDir()
+	keysDir := filepath.Join(dir, "keys")
+	proofDir := filepath.Join(dir, "proofs")
+
+	if err := runKeygen([]string{"-suite", "p256", "-dir", keysDir}); err != nil {
+		t.Fatalf("keygen: %v", err)
+	}
+
+	if err := runProve([]string{
+		"-suite", "p256", "-dir", keysDir, "-msg", "sample",
+		"-batchdir", proofDir, "-id", "entry1",
+	}); err != nil {
+		t.Fatalf("prove: %v", err)
+	}
+
+	if _, err := os.Stat(keystore.ProofPath(proofDir, "entry1")); err != nil {
+		t.Fatalf("prove -batchdir did not write a keystore-managed proof file: %v", err)
+	}
+
+	suite, err := lookupSuite("p256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := keystore.LoadProof(proofDir, "entry1", suite)
+	if err != nil {
+		t.Fatalf("LoadProof: %v", err)
+	}
+
+	kc, err := lookupKeyCoder("p256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := keystore.LoadPublicKey(keysDir, kc)
+	if err != nil {
+		t.Fatalf("LoadPublicKey: %v", err)
+	}
+	index, err := pk.ProofToHash([]byte("sample"), proof)
+	if err != nil {
+		t.Fatalf("ProofToHash: %v", err)
+	}
+
+	manifestPath := filepath.Join(dir, "manifest.txt")
+	manifest := "entry1 " + hex.EncodeToString([]byte("sample")) + " " + hex.EncodeToString(index[:]) + "\n"
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runBatchVerify([]string{
+		"-suite", "p256", "-dir", keysDir, "-proofdir", proofDir, "-manifest", manifestPath,
+	}); err != nil {
+		t.Fatalf("batch-verify: %v", err)
+	}
+}
+
+func TestProveRequiresIDWithBatchdir(t *testing.T) {
+	dir := t.TempDir()
+	keysDir := filepath.Join(dir, "keys")
+
+	if err := runKeygen([]string{"-suite", "p256", "-dir", keysDir}); err != nil {
+		t.Fatalf("keygen: %v", err)
+	}
+
+	err := runProve([]string{
+		"-suite", "p256", "-dir", keysDir, "-msg", "sample",
+		"-batchdir", filepath.Join(dir, "proofs"),
+	})
+	if err == nil {
+		t.Fatal("prove -batchdir without -id succeeded, want an error")
+	}
+}
@@ -0,0 +1,236 @@
+// Package ed25519 implements a verifiable random function on Curve25519,
+// mirroring the construction used by the coname VRF: Curve25519 in Edwards
+// form with cofactor 8, a hash-to-curve map built from SHA3 and the
+// Elligator 2 map, and Schnorr-style proofs of discrete-log equality.
+package ed25519
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/ursa-mikail/verifiable_random_functions/core/crypto/vrf"
+)
+
+// Proof layout: c (16 bytes, a truncated Schnorr challenge) || t (32 bytes,
+// a scalar) || ii (32 bytes, a compressed point).
+const (
+	challengeSize = 16
+	scalarSize    = 32
+	pointSize     = 32
+	proofSize     = challengeSize + scalarSize + pointSize
+)
+
+// ErrInvalidVRF occurs when the VRF proof does not validate.
+var ErrInvalidVRF = errors.New("ed25519vrf: invalid VRF proof")
+
+// PrivateKey holds a private VRF key.
+type PrivateKey struct {
+	scalar *edwards25519.Scalar
+	public *edwards25519.Point
+}
+
+// PublicKey holds a public VRF key.
+type PublicKey struct {
+	public *edwards25519.Point
+}
+
+// Suite is the ed25519 backend: an Elligator-based VRF on Curve25519.
+type Suite struct{}
+
+// Name returns "ed25519".
+func (Suite) Name() string {
+	return "ed25519"
+}
+
+// GenerateKey generates a fresh ed25519 VRF keypair.
+func (Suite) GenerateKey() (vrf.PrivateKey, vrf.PublicKey) {
+	return GenerateKey()
+}
+
+// MarshalPrivateKey encodes sk as its 32-byte scalar.
+func (Suite) MarshalPrivateKey(sk vrf.PrivateKey) ([]byte, error) {
+	k, ok := sk.(*PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("ed25519vrf: not an ed25519 private key: %T", sk)
+	}
+	return k.scalar.Bytes(), nil
+}
+
+// ParsePrivateKey decodes a key produced by MarshalPrivateKey.
+func (Suite) ParsePrivateKey(b []byte) (vrf.PrivateKey, error) {
+	scalar, err := new(edwards25519.Scalar).SetCanonicalBytes(b)
+	if err != nil {
+		return nil, ErrInvalidVRF
+	}
+	public := new(edwards25519.Point).ScalarBaseMult(scalar)
+	return &PrivateKey{scalar: scalar, public: public}, nil
+}
+
+// MarshalPublicKey encodes pk as its 32-byte compressed point.
+func (Suite) MarshalPublicKey(pk vrf.PublicKey) ([]byte, error) {
+	k, ok := pk.(*PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("ed25519vrf: not an ed25519 public key: %T", pk)
+	}
+	return k.public.Bytes(), nil
+}
+
+// ParsePublicKey decodes a key produced by MarshalPublicKey.
+func (Suite) ParsePublicKey(b []byte) (vrf.PublicKey, error) {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		return nil, ErrInvalidVRF
+	}
+	return &PublicKey{public: p}, nil
+}
+
+// GenerateKey generates a fresh ed25519 VRF keypair.
+func GenerateKey() (*PrivateKey, *PublicKey) {
+	var seed [32]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		return nil, nil
+	}
+	x, err := new(edwards25519.Scalar).SetBytesWithClamping(seed[:])
+	if err != nil {
+		return nil, nil
+	}
+	pub := new(edwards25519.Point).ScalarBaseMult(x)
+	return &PrivateKey{scalar: x, public: pub}, &PublicKey{public: pub}
+}
+
+// hashFieldElement reduces n to a uniformly random field element, the input
+// to the Elligator 2 map.
+func hashFieldElement(n []byte) *field.Element {
+	h := sha3.Sum512(append([]byte("ed25519vrf.H"), n...))
+	fe, err := new(field.Element).SetWideBytes(h[:])
+	if err != nil {
+		panic(err) // unreachable: h is always 64 bytes
+	}
+	return fe
+}
+
+// challenge computes the truncated Schnorr challenge c = SHA3(n, a, b).
+func challenge(n []byte, a, b *edwards25519.Point) []byte {
+	h := sha3.New256()
+	h.Write(n)
+	h.Write(a.Bytes())
+	h.Write(b.Bytes())
+	return h.Sum(nil)[:challengeSize]
+}
+
+// challengeScalar reduces a truncated challenge to a scalar. c is always
+// well within [0, l) since it is only challengeSize*8 = 128 bits wide.
+func challengeScalar(c []byte) (*edwards25519.Scalar, error) {
+	var buf [scalarSize]byte
+	copy(buf[:], c)
+	return new(edwards25519.Scalar).SetCanonicalBytes(buf[:])
+}
+
+// outputHash computes the VRF output SHA3(n, ii).
+func outputHash(n []byte, ii *edwards25519.Point) [32]byte {
+	h := sha3.New256()
+	h.Write(n)
+	h.Write(ii.Bytes())
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// Evaluate returns the verifiable unpredictable function evaluated at m.
+func (k *PrivateKey) Evaluate(m []byte) (index [32]byte, proof []byte) {
+	nilIndex := [32]byte{}
+
+	H := hashToCurve(hashFieldElement(m))
+	ii := new(edwards25519.Point).ScalarMult(k.scalar, H)
+
+	// r = SHA3(x || n), the deterministic nonce.
+	nonce := sha3.Sum512(append(k.scalar.Bytes(), m...))
+	r, err := new(edwards25519.Scalar).SetUniformBytes(nonce[:])
+	if err != nil {
+		return nilIndex, nil
+	}
+
+	gR := new(edwards25519.Point).ScalarBaseMult(r)
+	hR := new(edwards25519.Point).ScalarMult(r, H)
+
+	c := challenge(m, gR, hR)
+	cScalar, err := challengeScalar(c)
+	if err != nil {
+		return nilIndex, nil
+	}
+
+	// t = r - c*x mod l
+	negC := new(edwards25519.Scalar).Negate(cScalar)
+	t := new(edwards25519.Scalar).MultiplyAdd(negC, k.scalar, r)
+
+	var buf []byte
+	buf = append(buf, c...)
+	buf = append(buf, t.Bytes()...)
+	buf = append(buf, ii.Bytes()...)
+
+	return outputHash(m, ii), buf
+}
+
+// Public returns the corresponding public key.
+func (k *PrivateKey) Public() crypto.PublicKey {
+	return &PublicKey{public: k.public}
+}
+
+// ProofToHash asserts that proof is correct for m and outputs index.
+func (pk *PublicKey) ProofToHash(m, proof []byte) (index [32]byte, err error) {
+	nilIndex := [32]byte{}
+	if len(proof) != proofSize {
+		return nilIndex, ErrInvalidVRF
+	}
+
+	c := proof[0:challengeSize]
+	tBytes := proof[challengeSize : challengeSize+scalarSize]
+	iiBytes := proof[challengeSize+scalarSize:]
+
+	cScalar, err := challengeScalar(c)
+	if err != nil {
+		return nilIndex, ErrInvalidVRF
+	}
+	t, err := new(edwards25519.Scalar).SetCanonicalBytes(tBytes)
+	if err != nil {
+		return nilIndex, ErrInvalidVRF
+	}
+	ii, err := new(edwards25519.Point).SetBytes(iiBytes)
+	if err != nil {
+		return nilIndex, ErrInvalidVRF
+	}
+
+	H := hashToCurve(hashFieldElement(m))
+
+	// u1 = g^t * P^c
+	u1 := new(edwards25519.Point).ScalarBaseMult(t)
+	u1.Add(u1, new(edwards25519.Point).ScalarMult(cScalar, pk.public))
+
+	// u2 = H(n)^t * ii^c
+	u2 := new(edwards25519.Point).ScalarMult(t, H)
+	u2.Add(u2, new(edwards25519.Point).ScalarMult(cScalar, ii))
+
+	want := challenge(m, u1, u2)
+	if !constantTimeEqual(c, want) {
+		return nilIndex, ErrInvalidVRF
+	}
+
+	return outputHash(m, ii), nil
+}
+
+func constantTimeEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	var v byte
+	for i := range a {
+		v |= a[i] ^ b[i]
+	}
+	return v == 0
+}
@@ -0,0 +1,100 @@
+package ed25519
+
+import (
+	"bytes"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+func TestProveVerifyRoundTrip(t *testing.T) {
+	sk, pk := GenerateKey()
+	if sk == nil || pk == nil {
+		t.Fatal("GenerateKey failed")
+	}
+	msg := []byte("sample")
+
+	index, proof := sk.Evaluate(msg)
+	if proof == nil {
+		t.Fatal("Evaluate failed")
+	}
+
+	got, err := pk.ProofToHash(msg, proof)
+	if err != nil {
+		t.Fatalf("ProofToHash: %v", err)
+	}
+	if got != index {
+		t.Fatalf("ProofToHash index = %x, want %x", got, index)
+	}
+}
+
+func TestVerifyRejectsTamperedProof(t *testing.T) {
+	sk, pk := GenerateKey()
+	msg := []byte("sample")
+
+	_, proof := sk.Evaluate(msg)
+	proof[len(proof)-1] ^= 0xff
+
+	if _, err := pk.ProofToHash(msg, proof); err == nil {
+		t.Fatal("ProofToHash succeeded on a tampered proof")
+	}
+}
+
+func TestVerifyRejectsWrongMessage(t *testing.T) {
+	sk, pk := GenerateKey()
+
+	_, proof := sk.Evaluate([]byte("sample"))
+
+	if _, err := pk.ProofToHash([]byte("different"), proof); err == nil {
+		t.Fatal("ProofToHash succeeded against a message the proof wasn't computed over")
+	}
+}
+
+func TestEvaluateIsDeterministic(t *testing.T) {
+	sk, _ := GenerateKey()
+	msg := []byte("sample")
+
+	index1, proof1 := sk.Evaluate(msg)
+	index2, proof2 := sk.Evaluate(msg)
+
+	if index1 != index2 {
+		t.Fatalf("Evaluate index changed across calls: %x vs %x", index1, index2)
+	}
+	if !bytes.Equal(proof1, proof2) {
+		t.Fatalf("Evaluate proof changed across calls: %x vs %x", proof1, proof2)
+	}
+}
+
+// TestMapToCurveProducesValidPoints checks that hashing a variety of
+// messages through hashFieldElement and MapToCurve always lands on a valid
+// curve point (SetBytes round-trips), since hashToCurve has no retry loop.
+func TestMapToCurveProducesValidPoints(t *testing.T) {
+	for _, msg := range [][]byte{
+		[]byte(""),
+		[]byte("a"),
+		[]byte("the quick brown fox jumps over the lazy dog"),
+		bytes.Repeat([]byte{0xff}, 64),
+	} {
+		fe := hashFieldElement(msg)
+		p := MapToCurve(fe)
+		if _, err := new(edwards25519.Point).SetBytes(p.Bytes()); err != nil {
+			t.Fatalf("MapToCurve(%q) produced an invalid point: %v", msg, err)
+		}
+	}
+}
+
+func TestMapToCurveIsDeterministic(t *testing.T) {
+	var buf [32]byte
+	buf[0] = 0x2a
+	fe, err := new(field.Element).SetBytes(buf[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p1 := MapToCurve(fe)
+	p2 := MapToCurve(fe)
+	if !bytes.Equal(p1.Bytes(), p2.Bytes()) {
+		t.Fatalf("MapToCurve not deterministic: %x vs %x", p1.Bytes(), p2.Bytes())
+	}
+}
@@ -0,0 +1,122 @@
+package ed25519
+
+import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+// Curve25519 Montgomery coefficients: v^2 = u^3 + A*u^2 + u.
+const montgomeryA = 486662
+
+var (
+	feA        = feFromUint64(montgomeryA)
+	feOne      = new(field.Element).One()
+	feTwo      = feFromUint64(2)
+	feAPlus2   = feFromUint64(montgomeryA + 2)
+	sqrtNegAP2 = computeSqrtNegAPlus2()
+)
+
+// feFromUint64 builds the field element representing a small non-negative
+// integer.
+func feFromUint64(x uint64) *field.Element {
+	var buf [32]byte
+	for i := 0; i < 8; i++ {
+		buf[i] = byte(x >> (8 * i))
+	}
+	fe, err := new(field.Element).SetBytes(buf[:])
+	if err != nil {
+		panic(err) // unreachable: buf is always a valid 32-byte encoding
+	}
+	return fe
+}
+
+// computeSqrtNegAPlus2 returns sqrt(-(A+2)) mod p, the constant used to
+// convert a point from Montgomery (u,v) coordinates to Edwards (x,y)
+// coordinates: x = sqrt(-(A+2)) * u/v.
+func computeSqrtNegAPlus2() *field.Element {
+	neg := new(field.Element).Negate(feAPlus2)
+	r, _ := new(field.Element).SqrtRatio(neg, feOne)
+	return r
+}
+
+// elligator2 maps a uniformly random field element t onto a point (u, v) on
+// the Curve25519 Montgomery curve, following Bernstein, Hamburg,
+// Krasnokutsky and Lange's Elligator 2 construction with non-square
+// constant 2.
+func elligator2(t *field.Element) (u, v *field.Element) {
+	t2 := new(field.Element).Square(t)
+	t2.Multiply(t2, feTwo) // 2*t^2
+
+	d := new(field.Element).Add(feOne, t2) // 1 + 2*t^2
+	dInv := new(field.Element).Invert(d)
+
+	x1 := new(field.Element).Multiply(feA, dInv)
+	x1.Negate(x1) // x1 = -A / (1 + 2*t^2)
+
+	gx1 := montgomeryRHS(x1)
+
+	y1, isSquare := new(field.Element).SqrtRatio(gx1, feOne)
+	if isSquare == 1 {
+		return x1, y1
+	}
+
+	x2 := new(field.Element).Add(x1, feA)
+	x2.Negate(x2) // x2 = -x1 - A
+
+	gx2 := montgomeryRHS(x2)
+	y2, _ := new(field.Element).SqrtRatio(gx2, feOne)
+	return x2, y2
+}
+
+// montgomeryRHS evaluates u^3 + A*u^2 + u.
+func montgomeryRHS(u *field.Element) *field.Element {
+	u2 := new(field.Element).Square(u)
+	u3 := new(field.Element).Multiply(u2, u)
+	au2 := new(field.Element).Multiply(feA, u2)
+	rhs := new(field.Element).Add(u3, au2)
+	rhs.Add(rhs, u)
+	return rhs
+}
+
+// montgomeryToEdwards converts a point (u, v) on the Montgomery curve to the
+// birationally equivalent point on the twisted Edwards curve used by
+// ed25519, via y = (u-1)/(u+1) and x = sqrt(-(A+2)) * u/v.
+func montgomeryToEdwards(u, v *field.Element) *edwards25519.Point {
+	uMinus1 := new(field.Element).Subtract(u, feOne)
+	uPlus1 := new(field.Element).Add(u, feOne)
+	y := new(field.Element).Multiply(uMinus1, new(field.Element).Invert(uPlus1))
+
+	x := new(field.Element).Multiply(sqrtNegAP2, u)
+	x.Multiply(x, new(field.Element).Invert(v))
+
+	var buf [32]byte
+	copy(buf[:], y.Bytes())
+	if x.IsNegative() == 1 {
+		buf[31] |= 0x80
+	}
+
+	p, err := new(edwards25519.Point).SetBytes(buf[:])
+	if err != nil {
+		panic(err) // unreachable: (x, y) satisfies the curve equation by construction
+	}
+	return p
+}
+
+// hashToCurve is the f(...)^8 half of H(n) = f(SHA3(n))^8: t, the field
+// element hashFieldElement derives from n, is mapped onto the curve via
+// Elligator 2, and the cofactor is cleared so the result always lands in
+// the prime-order subgroup.
+func hashToCurve(t *field.Element) *edwards25519.Point {
+	return MapToCurve(t)
+}
+
+// MapToCurve applies the Elligator 2 map to a uniformly random field
+// element and clears the cofactor, landing the result in the prime-order
+// subgroup of Curve25519's Edwards form. It is exported so other Curve25519
+// VRF constructions (e.g. the RFC 9381 ELL2 ciphersuite) can reuse the same
+// curve arithmetic with their own hash-to-field step.
+func MapToCurve(t *field.Element) *edwards25519.Point {
+	u, v := elligator2(t)
+	p := montgomeryToEdwards(u, v)
+	return p.MultByCofactor(p)
+}
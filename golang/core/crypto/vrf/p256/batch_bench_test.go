@@ -0,0 +1,36 @@
+package p256
+
+import "testing"
+
+func benchmarkLoop(b *testing.B, n int) {
+	pk, msgs, proofs, _ := genBatch(b, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < n; j++ {
+			if _, err := pk.ProofToHash(msgs[j], proofs[j]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func benchmarkBatch(b *testing.B, n int) {
+	pk, msgs, proofs, indices := genBatch(b, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bv := NewBatchVerifier()
+		for j := 0; j < n; j++ {
+			bv.Add(pk, msgs[j], proofs[j], indices[j])
+		}
+		if ok, _ := bv.Verify(); !ok {
+			b.Fatal("batch verification failed")
+		}
+	}
+}
+
+func BenchmarkLoop64(b *testing.B)    { benchmarkLoop(b, 64) }
+func BenchmarkLoop256(b *testing.B)   { benchmarkLoop(b, 256) }
+func BenchmarkLoop1024(b *testing.B)  { benchmarkLoop(b, 1024) }
+func BenchmarkBatch64(b *testing.B)   { benchmarkBatch(b, 64) }
+func BenchmarkBatch256(b *testing.B)  { benchmarkBatch(b, 256) }
+func BenchmarkBatch1024(b *testing.B) { benchmarkBatch(b, 1024) }
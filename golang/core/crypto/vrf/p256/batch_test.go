@@ -0,0 +1,75 @@
+package p256
+
+import (
+	"testing"
+
+	ktp256 "github.com/google/keytransparency/core/crypto/vrf/p256"
+)
+
+func TestBatchVerifierAllValid(t *testing.T) {
+	pk, msgs, proofs, indices := genBatch(t, 8)
+
+	bv := NewBatchVerifier()
+	for i := range msgs {
+		bv.Add(pk, msgs[i], proofs[i], indices[i])
+	}
+
+	ok, bad := bv.Verify()
+	if !ok || len(bad) != 0 {
+		t.Fatalf("Verify() = %v, %v; want true, nil", ok, bad)
+	}
+}
+
+func TestBatchVerifierReportsBadIndices(t *testing.T) {
+	pk, msgs, proofs, indices := genBatch(t, 8)
+
+	bv := NewBatchVerifier()
+	for i := range msgs {
+		if i == 3 || i == 5 {
+			bv.Add(pk, msgs[i], proofs[i], [32]byte{0xff})
+			continue
+		}
+		bv.Add(pk, msgs[i], proofs[i], indices[i])
+	}
+
+	ok, bad := bv.Verify()
+	if ok {
+		t.Fatal("Verify() = true, want false")
+	}
+	if len(bad) != 2 || bad[0] != 3 || bad[1] != 5 {
+		t.Fatalf("badIndices = %v, want [3 5]", bad)
+	}
+}
+
+func TestBatchVerifierRejectsWrongKeyType(t *testing.T) {
+	_, msgs, proofs, indices := genBatch(t, 1)
+
+	bv := NewBatchVerifier()
+	bv.Add(notAP256Key{}, msgs[0], proofs[0], indices[0])
+
+	if ok, bad := bv.Verify(); ok || len(bad) != 1 {
+		t.Fatalf("Verify() = %v, %v; want false, [0]", ok, bad)
+	}
+}
+
+// notAP256Key satisfies vrf.PublicKey without being a *ktp256.PublicKey, to
+// exercise BatchVerifier's rejection of unsupported key types.
+type notAP256Key struct{}
+
+func (notAP256Key) ProofToHash(m, proof []byte) (index [32]byte, err error) {
+	return index, nil
+}
+
+func genBatch(tb testing.TB, n int) (*ktp256.PublicKey, [][]byte, [][]byte, [][32]byte) {
+	tb.Helper()
+
+	sk, pk := ktp256.GenerateKey()
+	msgs := make([][]byte, n)
+	proofs := make([][]byte, n)
+	indices := make([][32]byte, n)
+	for i := 0; i < n; i++ {
+		msgs[i] = []byte{byte(i), byte(i >> 8)}
+		indices[i], proofs[i] = sk.Evaluate(msgs[i])
+	}
+	return pk.(*ktp256.PublicKey), msgs, proofs, indices
+}
@@ -0,0 +1,61 @@
+// Package p256 adapts the keytransparency P-256 VRF construction to the
+// local vrf.Suite interface.
+package p256
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	ktp256 "github.com/google/keytransparency/core/crypto/vrf/p256"
+
+	"github.com/ursa-mikail/verifiable_random_functions/core/crypto/vrf"
+)
+
+// Suite is the P-256 backend: ECDSA-style VRF on the NIST P-256 curve.
+type Suite struct{}
+
+// GenerateKey generates a fresh P-256 VRF keypair.
+func (Suite) GenerateKey() (vrf.PrivateKey, vrf.PublicKey) {
+	return ktp256.GenerateKey()
+}
+
+// Name returns "p256".
+func (Suite) Name() string {
+	return "p256"
+}
+
+// MarshalPrivateKey encodes sk as an ASN.1 DER EC private key, the same raw
+// format accepted by ktp256.NewVRFSignerFromRawKey.
+func (Suite) MarshalPrivateKey(sk vrf.PrivateKey) ([]byte, error) {
+	k, ok := sk.(*ktp256.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("p256: not a p256 private key: %T", sk)
+	}
+	return x509.MarshalECPrivateKey(k.PrivateKey)
+}
+
+// ParsePrivateKey decodes a key produced by MarshalPrivateKey.
+func (Suite) ParsePrivateKey(b []byte) (vrf.PrivateKey, error) {
+	return ktp256.NewVRFSignerFromRawKey(b)
+}
+
+// MarshalPublicKey encodes pk as an ASN.1 DER SubjectPublicKeyInfo, the
+// same raw format accepted by ktp256.NewVRFVerifierFromRawKey.
+func (Suite) MarshalPublicKey(pk vrf.PublicKey) ([]byte, error) {
+	k, ok := pk.(*ktp256.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("p256: not a p256 public key: %T", pk)
+	}
+	return x509.MarshalPKIXPublicKey(k.PublicKey)
+}
+
+// ParsePublicKey decodes a key produced by MarshalPublicKey.
+func (Suite) ParsePublicKey(b []byte) (vrf.PublicKey, error) {
+	return ktp256.NewVRFVerifierFromRawKey(b)
+}
+
+// NewBatchVerifier returns a fresh BatchVerifier for amortized verification
+// of many P-256 VRF proofs at once.
+func (Suite) NewBatchVerifier() vrf.BatchVerifier {
+	return NewBatchVerifier()
+}
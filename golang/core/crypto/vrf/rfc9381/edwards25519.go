@@ -0,0 +1,171 @@
+package rfc9381
+
+import (
+	"bytes"
+	"crypto/sha512"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"github.com/ursa-mikail/verifiable_random_functions/core/crypto/vrf/ed25519"
+)
+
+// Edwards25519 implements ECVRF-EDWARDS25519-SHA512-ELL2: hash-to-curve via
+// the Elligator 2 map, SHA-512 as the hash function, and cofactor 8.
+type Edwards25519 struct{}
+
+const (
+	edwSuiteString = 0x04
+	edwCLen        = 16
+	edwQLen        = 32
+)
+
+// edwSecretKey is the expanded form of a 32-byte seed: a clamped scalar and
+// the SHA-512 "prefix" half used for nonce generation, as in RFC 8032.
+type edwSecretKey struct {
+	scalar *edwards25519.Scalar
+	prefix []byte
+	public *edwards25519.Point
+}
+
+func expandSeed(seed []byte) (*edwSecretKey, error) {
+	h := sha512.Sum512(seed)
+	scalar, err := new(edwards25519.Scalar).SetBytesWithClamping(h[:32])
+	if err != nil {
+		return nil, err
+	}
+	public := new(edwards25519.Point).ScalarBaseMult(scalar)
+	return &edwSecretKey{scalar: scalar, prefix: h[32:], public: public}, nil
+}
+
+// Prove computes the VRF proof pi and output beta for alpha under the
+// 32-byte seed sk.
+func (Edwards25519) Prove(sk, alpha []byte) (pi, beta []byte, err error) {
+	esk, err := expandSeed(sk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	H := edwHashToCurve(esk.public, alpha)
+	Gamma := new(edwards25519.Point).ScalarMult(esk.scalar, H)
+
+	nonce := sha512.Sum512(append(append([]byte{}, esk.prefix...), H.Bytes()...))
+	k, err := new(edwards25519.Scalar).SetUniformBytes(nonce[:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kB := new(edwards25519.Point).ScalarBaseMult(k)
+	kH := new(edwards25519.Point).ScalarMult(k, H)
+
+	cBytes := edwHashPoints(H, Gamma, kB, kH)
+	c, err := edwScalarFromChallenge(cBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := new(edwards25519.Scalar).MultiplyAdd(c, esk.scalar, k)
+
+	pi = make([]byte, 0, edwQLen+edwCLen+edwQLen)
+	pi = append(pi, Gamma.Bytes()...)
+	pi = append(pi, cBytes...)
+	pi = append(pi, s.Bytes()...)
+
+	return pi, edwProofToHash(Gamma), nil
+}
+
+// Verify checks pi against alpha under the 32-byte compressed public point
+// pk and returns the VRF output.
+func (Edwards25519) Verify(pk, alpha, pi []byte) (beta []byte, err error) {
+	if len(pi) != edwQLen+edwCLen+edwQLen {
+		return nil, ErrInvalidProof
+	}
+	Y, err := new(edwards25519.Point).SetBytes(pk)
+	if err != nil {
+		return nil, ErrInvalidProof
+	}
+	Gamma, err := new(edwards25519.Point).SetBytes(pi[0:edwQLen])
+	if err != nil {
+		return nil, ErrInvalidProof
+	}
+	cBytes := pi[edwQLen : edwQLen+edwCLen]
+	c, err := edwScalarFromChallenge(cBytes)
+	if err != nil {
+		return nil, ErrInvalidProof
+	}
+	s, err := new(edwards25519.Scalar).SetCanonicalBytes(pi[edwQLen+edwCLen:])
+	if err != nil {
+		return nil, ErrInvalidProof
+	}
+
+	H := edwHashToCurve(Y, alpha)
+
+	negC := new(edwards25519.Scalar).Negate(c)
+
+	// U = s*B - c*Y
+	U := new(edwards25519.Point).ScalarBaseMult(s)
+	U.Add(U, new(edwards25519.Point).ScalarMult(negC, Y))
+
+	// V = s*H - c*Gamma
+	V := new(edwards25519.Point).ScalarMult(s, H)
+	V.Add(V, new(edwards25519.Point).ScalarMult(negC, Gamma))
+
+	want := edwHashPoints(H, Gamma, U, V)
+	if !bytes.Equal(want, cBytes) {
+		return nil, ErrInvalidProof
+	}
+
+	return edwProofToHash(Gamma), nil
+}
+
+// edwHashToCurve implements the ELL2 hash-to-curve step: hash (suite
+// string, Y, alpha) to 64 bytes, reduce the first 32 (with the top bit
+// cleared, matching field.Element.SetBytes) to a field element, and map it
+// onto the curve via Elligator 2.
+func edwHashToCurve(Y *edwards25519.Point, alpha []byte) *edwards25519.Point {
+	h := sha512.New()
+	h.Write([]byte{edwSuiteString, hashToCurveDomain})
+	h.Write(Y.Bytes())
+	h.Write(alpha)
+	digest := h.Sum(nil)
+
+	t, err := new(field.Element).SetBytes(digest[:32])
+	if err != nil {
+		panic(err) // unreachable: digest[:32] is always 32 bytes
+	}
+	return ed25519.MapToCurve(t)
+}
+
+// edwHashPoints implements ECVRF_hash_points for the edwards25519 suite.
+func edwHashPoints(points ...*edwards25519.Point) []byte {
+	h := sha512.New()
+	h.Write([]byte{edwSuiteString, challengeDomain})
+	for _, p := range points {
+		h.Write(p.Bytes())
+	}
+	h.Write([]byte{domainSuffix})
+	return h.Sum(nil)[:edwCLen]
+}
+
+// edwProofToHash implements ECVRF_proof_to_hash: beta_string =
+// Hash(suite_string || 0x03 || point_to_string(cofactor*Gamma) || 0x00).
+// Gamma being in the prime-order subgroup does not make 8*Gamma == Gamma
+// (that would require 8 = 1 mod l), so the cofactor multiplication here is
+// not optional.
+func edwProofToHash(Gamma *edwards25519.Point) []byte {
+	Gamma8 := new(edwards25519.Point).MultByCofactor(Gamma)
+
+	h := sha512.New()
+	h.Write([]byte{edwSuiteString, proofToHashDomain})
+	h.Write(Gamma8.Bytes())
+	h.Write([]byte{domainSuffix})
+	return h.Sum(nil)
+}
+
+// edwScalarFromChallenge zero-extends a cLen-byte challenge to a full
+// scalar. It is always canonical: cLen*8 = 128 bits is far below l.
+func edwScalarFromChallenge(c []byte) (*edwards25519.Scalar, error) {
+	var buf [edwQLen]byte
+	copy(buf[:], c)
+	return new(edwards25519.Scalar).SetCanonicalBytes(buf[:])
+}
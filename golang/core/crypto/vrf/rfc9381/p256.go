@@ -0,0 +1,200 @@
+package rfc9381
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+)
+
+// P256 implements ECVRF-P256-SHA256-TAI: hash-to-curve by try-and-increment,
+// SHA-256 as the hash function, and cofactor 1 (P-256 has no cofactor).
+type P256 struct{}
+
+const (
+	p256SuiteString = 0x01
+	p256CLen        = 16
+	p256QLen        = 32
+)
+
+var (
+	p256Curve  = elliptic.P256()
+	p256Params = p256Curve.Params()
+)
+
+// Prove computes the VRF proof pi and output beta for alpha under sk.
+func (P256) Prove(sk *ecdsa.PrivateKey, alpha []byte) (pi, beta []byte, err error) {
+	Y := sk.PublicKey
+	Hx, Hy, err := p256HashToCurveTAI(&Y, alpha)
+	if err != nil {
+		return nil, nil, err
+	}
+	hString := elliptic.MarshalCompressed(p256Curve, Hx, Hy)
+
+	Gx, Gy := p256Curve.ScalarMult(Hx, Hy, sk.D.Bytes())
+
+	k := p256NonceRFC6979(sk.D, hString)
+
+	kGx, kGy := p256Curve.ScalarBaseMult(k.Bytes())
+	kHx, kHy := p256Curve.ScalarMult(Hx, Hy, k.Bytes())
+
+	cBytes := p256HashPoints(Hx, Hy, Gx, Gy, kGx, kGy, kHx, kHy)
+	c := new(big.Int).SetBytes(cBytes)
+
+	s := new(big.Int).Mul(c, sk.D)
+	s.Add(s, k)
+	s.Mod(s, p256Params.N)
+
+	pi = make([]byte, 0, 33+p256CLen+p256QLen)
+	pi = append(pi, elliptic.MarshalCompressed(p256Curve, Gx, Gy)...)
+	pi = append(pi, cBytes...)
+	pi = append(pi, leftPad(s.Bytes(), p256QLen)...)
+
+	beta = p256ProofToHash(Gx, Gy)
+	return pi, beta, nil
+}
+
+// Verify checks pi against alpha under pk and returns the VRF output.
+func (P256) Verify(pk *ecdsa.PublicKey, alpha, pi []byte) (beta []byte, err error) {
+	if len(pi) != 33+p256CLen+p256QLen {
+		return nil, ErrInvalidProof
+	}
+	Gx, Gy := elliptic.UnmarshalCompressed(p256Curve, pi[0:33])
+	if Gx == nil {
+		return nil, ErrInvalidProof
+	}
+	cBytes := pi[33 : 33+p256CLen]
+	c := new(big.Int).SetBytes(cBytes)
+	s := new(big.Int).SetBytes(pi[33+p256CLen:])
+	if s.Cmp(p256Params.N) >= 0 {
+		return nil, ErrInvalidProof
+	}
+
+	Hx, Hy, err := p256HashToCurveTAI(pk, alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	// U = s*G - c*Y
+	sGx, sGy := p256Curve.ScalarBaseMult(s.Bytes())
+	negC := new(big.Int).Sub(p256Params.N, c)
+	negC.Mod(negC, p256Params.N)
+	cYx, cYy := p256Curve.ScalarMult(pk.X, pk.Y, negC.Bytes())
+	Ux, Uy := p256Curve.Add(sGx, sGy, cYx, cYy)
+
+	// V = s*H - c*Gamma
+	sHx, sHy := p256Curve.ScalarMult(Hx, Hy, s.Bytes())
+	cGx, cGy := p256Curve.ScalarMult(Gx, Gy, negC.Bytes())
+	Vx, Vy := p256Curve.Add(sHx, sHy, cGx, cGy)
+
+	want := p256HashPoints(Hx, Hy, Gx, Gy, Ux, Uy, Vx, Vy)
+	if !bytes.Equal(want, cBytes) {
+		return nil, ErrInvalidProof
+	}
+
+	return p256ProofToHash(Gx, Gy), nil
+}
+
+// p256HashToCurveTAI implements the try-and-increment hash-to-curve method:
+// repeatedly hash (suite_string, Y, alpha, ctr) and interpret the digest as
+// a compressed point, until a valid one is found.
+func p256HashToCurveTAI(Y *ecdsa.PublicKey, alpha []byte) (x, y *big.Int, err error) {
+	pkString := elliptic.MarshalCompressed(p256Curve, Y.X, Y.Y)
+	for ctr := 0; ctr < 256; ctr++ {
+		h := sha256.New()
+		h.Write([]byte{p256SuiteString, hashToCurveDomain})
+		h.Write(pkString)
+		h.Write(alpha)
+		h.Write(i2osp(ctr, 1))
+		h.Write([]byte{domainSuffix})
+		digest := h.Sum(nil)
+
+		candidate := append([]byte{0x02}, digest...)
+		x, y = elliptic.UnmarshalCompressed(p256Curve, candidate)
+		if x != nil {
+			return x, y, nil
+		}
+	}
+	return nil, nil, ErrInvalidProof
+}
+
+// p256HashPoints implements ECVRF_hash_points: hash four curve points
+// together with the domain separators and truncate to cLen bytes.
+func p256HashPoints(points ...*big.Int) []byte {
+	h := sha256.New()
+	h.Write([]byte{p256SuiteString, challengeDomain})
+	for i := 0; i < len(points); i += 2 {
+		h.Write(elliptic.MarshalCompressed(p256Curve, points[i], points[i+1]))
+	}
+	h.Write([]byte{domainSuffix})
+	return h.Sum(nil)[:p256CLen]
+}
+
+// p256ProofToHash implements ECVRF_proof_to_hash given Gamma (P-256 has
+// cofactor 1, so no cofactor clearing is needed).
+func p256ProofToHash(Gx, Gy *big.Int) []byte {
+	h := sha256.New()
+	h.Write([]byte{p256SuiteString, proofToHashDomain})
+	h.Write(elliptic.MarshalCompressed(p256Curve, Gx, Gy))
+	h.Write([]byte{domainSuffix})
+	return h.Sum(nil)
+}
+
+// p256NonceRFC6979 deterministically derives a nonce from the private
+// scalar x and a message, following RFC 6979 with SHA-256/HMAC-SHA-256.
+func p256NonceRFC6979(x *big.Int, message []byte) *big.Int {
+	q := p256Params.N
+	qlen := p256QLen
+
+	xBytes := leftPad(x.Bytes(), qlen)
+	h1 := sha256.Sum256(message)
+	bitsToOctets := bits2octets(h1[:], q, qlen)
+
+	hlen := sha256.Size
+	v := bytes.Repeat([]byte{0x01}, hlen)
+	k := make([]byte, hlen)
+
+	hmacSum := func(key, msg []byte) []byte {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(msg)
+		return mac.Sum(nil)
+	}
+
+	k = hmacSum(k, append(append(append(append([]byte{}, v...), 0x00), xBytes...), bitsToOctets...))
+	v = hmacSum(k, v)
+	k = hmacSum(k, append(append(append(append([]byte{}, v...), 0x01), xBytes...), bitsToOctets...))
+	v = hmacSum(k, v)
+
+	for {
+		var t []byte
+		for len(t) < qlen {
+			v = hmacSum(k, v)
+			t = append(t, v...)
+		}
+		candidate := new(big.Int).SetBytes(t[:qlen])
+		if candidate.Sign() > 0 && candidate.Cmp(q) < 0 {
+			return candidate
+		}
+		k = hmacSum(k, append(v, 0x00))
+		v = hmacSum(k, v)
+	}
+}
+
+// bits2octets implements the RFC 6979 bits2octets transform: reduce h1 mod
+// q and re-encode as qlen bytes.
+func bits2octets(h1 []byte, q *big.Int, qlen int) []byte {
+	z := new(big.Int).SetBytes(h1)
+	z.Mod(z, q)
+	return leftPad(z.Bytes(), qlen)
+}
+
+func leftPad(b []byte, n int) []byte {
+	if len(b) >= n {
+		return b[len(b)-n:]
+	}
+	out := make([]byte, n)
+	copy(out[n-len(b):], b)
+	return out
+}
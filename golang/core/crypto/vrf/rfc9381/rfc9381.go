@@ -0,0 +1,42 @@
+// Package rfc9381 implements two ECVRF ciphersuites modelled on RFC 9381,
+// ECVRF-P256-SHA256-TAI and ECVRF-EDWARDS25519-SHA512-ELL2: proofs are
+// encoded as pi = gamma || c || s (gamma a compressed curve point, c a
+// half-width challenge, s a full-width scalar), and outputs as
+// beta = Hash(suite_string || 0x03 || point_to_string(cofactor*gamma) || 0x00).
+//
+// The challenge c is computed over H, Gamma, U and V only; the public key Y
+// is not hashed into it. The EDWARDS25519 suite hashes to the curve by
+// reducing the first 32 bytes of a SHA-512 digest to a field element and
+// applying Elligator 2 directly, not through the RFC 9380 hash_to_curve
+// construction. Proofs from either suite, and outputs from the EDWARDS25519
+// suite, therefore do not match those of other RFC 9381 implementations.
+// The known-answer tests in rfc9381_test.go pin this implementation's own
+// output; they are not the RFC's Appendix A test vectors.
+//
+// This is deliberately a from-scratch implementation rather than a wrapper
+// around the keytransparency p256 package: that package's proof encoding
+// predates RFC 9381 and is not compatible with it.
+package rfc9381
+
+import "errors"
+
+// ErrInvalidProof occurs when a proof fails to parse or verify.
+var ErrInvalidProof = errors.New("rfc9381: invalid VRF proof")
+
+// Domain-separating prefix/suffix bytes from RFC 9381 Section 5.4.1.
+const (
+	hashToCurveDomain = 0x01
+	challengeDomain   = 0x02
+	proofToHashDomain = 0x03
+	domainSuffix      = 0x00
+)
+
+// i2osp encodes x as a big-endian byte string of exactly n bytes.
+func i2osp(x int, n int) []byte {
+	buf := make([]byte, n)
+	for i := n - 1; i >= 0 && x > 0; i-- {
+		buf[i] = byte(x)
+		x >>= 8
+	}
+	return buf
+}
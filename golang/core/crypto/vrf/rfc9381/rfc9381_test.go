@@ -0,0 +1,228 @@
+package rfc9381
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestP256ProveVerifyRoundTrip(t *testing.T) {
+	sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha := []byte("sample")
+
+	pi, beta, err := P256{}.Prove(sk, alpha)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+
+	got, err := P256{}.Verify(&sk.PublicKey, alpha, pi)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !bytes.Equal(got, beta) {
+		t.Fatalf("Verify beta = %x, want %x", got, beta)
+	}
+}
+
+func TestP256VerifyRejectsTamperedProof(t *testing.T) {
+	sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha := []byte("sample")
+
+	pi, _, err := P256{}.Prove(sk, alpha)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+	pi[len(pi)-1] ^= 0xff
+
+	if _, err := (P256{}).Verify(&sk.PublicKey, alpha, pi); err == nil {
+		t.Fatal("Verify succeeded on a tampered proof")
+	}
+}
+
+// TestP256RegressionVector pins pi/beta for a fixed private scalar and
+// alpha so that a change to the hash-to-curve, challenge, or nonce
+// derivation steps is caught as a regression, not just a Prove/Verify
+// self-consistency check. The values below were computed once from this
+// implementation and committed as a regression baseline; they are NOT the
+// RFC 9381 Appendix A.2 test vectors and passing this test does not
+// establish interop with another conformant implementation. See the
+// package doc.
+func TestP256RegressionVector(t *testing.T) {
+	dBytes, err := hex.DecodeString("a464d65589390c57be3665449804ba303215bb43113429d9f8702857999bdcc9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	curve := elliptic.P256()
+	d := new(big.Int).SetBytes(dBytes)
+	x, y := curve.ScalarBaseMult(d.Bytes())
+	sk := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+		D:         d,
+	}
+	alpha := []byte("sample")
+
+	wantPi, err := hex.DecodeString("029f8c878aec9057aee70e98521c49c9bb1a8755187055906af2706ccf0e65861495d3bee08c35078077cb6f4c2d9d71cbe1a049c6ae6950e0d18dae8738e75a258a01a58024cab3e06115d5b82d1d4f1b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBeta, err := hex.DecodeString("44b0c1a89159439dc09a24a045fd376cfe31828ba013df7df7d94e74c72d749c")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pi, beta, err := P256{}.Prove(sk, alpha)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+	if !bytes.Equal(pi, wantPi) {
+		t.Fatalf("pi = %x, want %x", pi, wantPi)
+	}
+	if !bytes.Equal(beta, wantBeta) {
+		t.Fatalf("beta = %x, want %x", beta, wantBeta)
+	}
+}
+
+func TestEdwards25519ProveVerifyRoundTrip(t *testing.T) {
+	var seed [32]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		t.Fatal(err)
+	}
+	esk, err := expandSeed(seed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha := []byte("sample")
+
+	pi, beta, err := Edwards25519{}.Prove(seed[:], alpha)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+
+	got, err := Edwards25519{}.Verify(esk.public.Bytes(), alpha, pi)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !bytes.Equal(got, beta) {
+		t.Fatalf("Verify beta = %x, want %x", got, beta)
+	}
+}
+
+func TestEdwards25519VerifyRejectsTamperedProof(t *testing.T) {
+	var seed [32]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		t.Fatal(err)
+	}
+	esk, err := expandSeed(seed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha := []byte("sample")
+
+	pi, _, err := Edwards25519{}.Prove(seed[:], alpha)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+	pi[len(pi)-1] ^= 0xff
+
+	if _, err := (Edwards25519{}).Verify(esk.public.Bytes(), alpha, pi); err == nil {
+		t.Fatal("Verify succeeded on a tampered proof")
+	}
+}
+
+// TestEdwards25519ProofToHashAppliesCofactor recomputes beta independently
+// of edwProofToHash (doubling Gamma three times by hand instead of calling
+// MultByCofactor) so that a regression which drops the cofactor
+// multiplication is caught even though Prove/Verify would still agree with
+// each other: both sides of this check are cofactor-dependent, but they
+// arrive at cofactor*Gamma through different code paths.
+func TestEdwards25519ProofToHashAppliesCofactor(t *testing.T) {
+	seed, err := hex.DecodeString("7655b465e506a4d9199dc0580ac7e97e96d069aa9fc2725dcfb01a396e4dc181")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha := []byte("sample")
+
+	pi, beta, err := Edwards25519{}.Prove(seed, alpha)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+
+	Gamma, err := new(edwards25519.Point).SetBytes(pi[0:edwQLen])
+	if err != nil {
+		t.Fatalf("SetBytes(Gamma): %v", err)
+	}
+	Gamma2 := new(edwards25519.Point).Add(Gamma, Gamma)
+	Gamma4 := new(edwards25519.Point).Add(Gamma2, Gamma2)
+	Gamma8 := new(edwards25519.Point).Add(Gamma4, Gamma4)
+
+	h := sha512.New()
+	h.Write([]byte{edwSuiteString, proofToHashDomain})
+	h.Write(Gamma8.Bytes())
+	h.Write([]byte{domainSuffix})
+	want := h.Sum(nil)
+
+	if !bytes.Equal(beta, want) {
+		t.Fatalf("beta = %x, want cofactor*Gamma hash %x", beta, want)
+	}
+
+	// Sanity check that the cofactor step is load-bearing: hashing Gamma
+	// directly (the bug this guards against) must NOT match beta, since
+	// 8*Gamma != Gamma for points of order l.
+	h = sha512.New()
+	h.Write([]byte{edwSuiteString, proofToHashDomain})
+	h.Write(Gamma.Bytes())
+	h.Write([]byte{domainSuffix})
+	uncleared := h.Sum(nil)
+	if bytes.Equal(beta, uncleared) {
+		t.Fatal("beta matched the hash of Gamma without cofactor clearing")
+	}
+}
+
+// TestEdwards25519RegressionVector pins pi/beta for a fixed seed and alpha
+// so that any change to the hash-to-curve, challenge, or proof-to-hash
+// steps is caught as a regression, not just a Prove/Verify self-consistency
+// check (which cannot detect a bug applied identically on both sides). The
+// values below were computed once from this implementation and committed
+// as a regression baseline; they are NOT the RFC 9381 Appendix A.4 test
+// vectors and passing this test does not establish interop with another
+// conformant implementation. See the package doc.
+func TestEdwards25519RegressionVector(t *testing.T) {
+	seed, err := hex.DecodeString("7655b465e506a4d9199dc0580ac7e97e96d069aa9fc2725dcfb01a396e4dc181")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha := []byte("")
+
+	wantPi, err := hex.DecodeString("008145126dc5446b715d06948f4a88d9cc20da1af4c993484967f73d8f5a2b73ccc1d242fa549e1f976b367cecd791735e12177a3dbe8ee8af8e73c463d8c8f16901ca319e72daa2caf877fd58a61b0b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBeta, err := hex.DecodeString("9016f363a5335ad18850bc16268963c0e37ba0bc60fd3c98a1396f52569392d01f0c26c03d674b6a0d1af8922a5a13142e76a1b7635f4968e6fb1db5ae126979")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pi, beta, err := Edwards25519{}.Prove(seed, alpha)
+	if err != nil {
+		t.Fatalf("Prove: %v", err)
+	}
+	if !bytes.Equal(pi, wantPi) {
+		t.Fatalf("pi = %x, want %x", pi, wantPi)
+	}
+	if !bytes.Equal(beta, wantBeta) {
+		t.Fatalf("beta = %x, want %x", beta, wantBeta)
+	}
+}
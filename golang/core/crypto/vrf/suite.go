@@ -0,0 +1,69 @@
+// Package vrf defines a pluggable verifiable random function construction.
+//
+// A Suite picks a concrete elliptic-curve backend (P-256, ed25519, ...) and
+// is responsible only for key generation; the resulting keys carry the
+// Evaluate/ProofToHash operations, reusing the same PrivateKey/PublicKey
+// shape as github.com/google/keytransparency/core/crypto/vrf so existing
+// callers of either backend are interchangeable.
+package vrf
+
+import ktvrf "github.com/google/keytransparency/core/crypto/vrf"
+
+// PrivateKey supports evaluating the VRF function.
+type PrivateKey = ktvrf.PrivateKey
+
+// PublicKey supports verifying output from the VRF function.
+type PublicKey = ktvrf.PublicKey
+
+// Suite is a pluggable VRF construction that callers can select at runtime,
+// e.g. via a command-line flag.
+type Suite interface {
+	// GenerateKey returns a fresh keypair for this suite.
+	GenerateKey() (PrivateKey, PublicKey)
+
+	// Name identifies the suite, e.g. for use in flag values or logs.
+	Name() string
+}
+
+// BatchVerifier is implemented by suites that support amortizing the cost
+// of verifying many proofs at once, falling back to per-entry diagnostics
+// when the batch doesn't fully verify.
+type BatchVerifier interface {
+	// Add queues a proof for verification.
+	Add(pk PublicKey, msg, proof []byte, expectedIndex [32]byte)
+
+	// Verify checks every queued proof and returns the indices of the
+	// ones that failed.
+	Verify() (ok bool, badIndices []int)
+}
+
+// BatchVerifierFactory is implemented by suites that can construct a
+// BatchVerifier for amortized verification of many proofs at once.
+type BatchVerifierFactory interface {
+	Suite
+
+	// NewBatchVerifier returns a fresh, empty BatchVerifier for this suite.
+	NewBatchVerifier() BatchVerifier
+}
+
+// KeyCoder is implemented by suites whose keys can be marshaled to and
+// parsed back from bytes, e.g. so the keystore package can persist them to
+// disk. Not every suite needs this, so it is kept separate from Suite
+// rather than folded into it.
+type KeyCoder interface {
+	Suite
+
+	// MarshalPrivateKey encodes sk in this suite's on-disk format.
+	MarshalPrivateKey(sk PrivateKey) ([]byte, error)
+
+	// ParsePrivateKey decodes a private key previously produced by
+	// MarshalPrivateKey.
+	ParsePrivateKey(b []byte) (PrivateKey, error)
+
+	// MarshalPublicKey encodes pk in this suite's on-disk format.
+	MarshalPublicKey(pk PublicKey) ([]byte, error)
+
+	// ParsePublicKey decodes a public key previously produced by
+	// MarshalPublicKey.
+	ParsePublicKey(b []byte) (PublicKey, error)
+}
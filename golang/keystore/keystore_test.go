@@ -0,0 +1,79 @@
+package keystore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ursa-mikail/verifiable_random_functions/core/crypto/vrf/p256"
+)
+
+func TestSaveLoadKeyRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	suite := p256.Suite{}
+
+	sk, pk := suite.GenerateKey()
+	if err := SavePrivateKey(dir, suite, sk); err != nil {
+		t.Fatalf("SavePrivateKey: %v", err)
+	}
+	if err := SavePublicKey(dir, suite, pk); err != nil {
+		t.Fatalf("SavePublicKey: %v", err)
+	}
+
+	loadedSK, err := LoadPrivateKey(dir, suite)
+	if err != nil {
+		t.Fatalf("LoadPrivateKey: %v", err)
+	}
+	loadedPK, err := LoadPublicKey(dir, suite)
+	if err != nil {
+		t.Fatalf("LoadPublicKey: %v", err)
+	}
+
+	msg := []byte("sample")
+	wantIndex, proof := loadedSK.Evaluate(msg)
+	gotIndex, err := loadedPK.ProofToHash(msg, proof)
+	if err != nil {
+		t.Fatalf("ProofToHash: %v", err)
+	}
+	if gotIndex != wantIndex {
+		t.Fatalf("ProofToHash index = %x, want %x", gotIndex, wantIndex)
+	}
+}
+
+func TestSaveLoadProofRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	suite := p256.Suite{}
+
+	proof := []byte{0x01, 0x02, 0x03, 0x04}
+	if err := SaveProof(dir, "entry1", suite, proof); err != nil {
+		t.Fatalf("SaveProof: %v", err)
+	}
+
+	got, err := LoadProof(dir, "entry1", suite)
+	if err != nil {
+		t.Fatalf("LoadProof: %v", err)
+	}
+	if !bytes.Equal(got, proof) {
+		t.Fatalf("LoadProof = %x, want %x", got, proof)
+	}
+}
+
+func TestLoadRejectsWrongSuite(t *testing.T) {
+	dir := t.TempDir()
+	p256Suite := p256.Suite{}
+
+	sk, _ := p256Suite.GenerateKey()
+	if err := SavePrivateKey(dir, p256Suite, sk); err != nil {
+		t.Fatalf("SavePrivateKey: %v", err)
+	}
+
+	if _, err := LoadPrivateKey(dir, fakeSuite{}); err == nil {
+		t.Fatal("LoadPrivateKey succeeded despite a suite mismatch")
+	}
+}
+
+// fakeSuite has a distinct Name() from p256.Suite so LoadPrivateKey's
+// suite-header check can be exercised without a second real suite
+// implementing vrf.KeyCoder.
+type fakeSuite struct{ p256.Suite }
+
+func (fakeSuite) Name() string { return "fake" }
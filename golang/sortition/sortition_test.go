@@ -0,0 +1,148 @@
+package sortition
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestSelectZeroWeightOrTotalWeight(t *testing.T) {
+	var idx [32]byte
+	if got, err := Select(idx, 0, 100, 1); got != 0 || err != nil {
+		t.Fatalf("Select with weight=0 = %d, %v; want 0, nil", got, err)
+	}
+	if got, err := Select(idx, 100, 0, 1); got != 0 || err != nil {
+		t.Fatalf("Select with totalWeight=0 = %d, %v; want 0, nil", got, err)
+	}
+}
+
+func TestSelectMonotonicInIndex(t *testing.T) {
+	// Holding weight/totalWeight/expectedSize fixed, a larger VRF output
+	// (interpreted as a larger ratio) must never select fewer sub-users.
+	weight, totalWeight, expectedSize := uint64(1000), uint64(10_000), 100.0
+
+	var prev uint64
+	for _, b := range []byte{0x00, 0x10, 0x40, 0x80, 0xc0, 0xff} {
+		var idx [32]byte
+		idx[0] = b
+		got, err := Select(idx, weight, totalWeight, expectedSize)
+		if err != nil {
+			t.Fatalf("Select(idx[0]=%#x): %v", b, err)
+		}
+		if got < prev {
+			t.Fatalf("Select(idx[0]=%#x) = %d, less than previous %d", b, got, prev)
+		}
+		prev = got
+	}
+}
+
+func TestSelectBoundedByWeight(t *testing.T) {
+	var idx [32]byte
+	for i := range idx {
+		idx[i] = 0xff
+	}
+	got, err := Select(idx, 50, 100, 10_000)
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if got > 50 {
+		t.Fatalf("Select() = %d, must not exceed weight 50", got)
+	}
+}
+
+// TestSelectRejectsWeightAboveMax guards the DoS vector a caller-supplied
+// weight could otherwise open up: rather than running the exact O(weight)
+// binomial CDF walk unbounded (or silently swapping in an approximation
+// that could disagree with another verifier's exact computation), Select
+// must reject weight above MaxWeight outright.
+func TestSelectRejectsWeightAboveMax(t *testing.T) {
+	var idx [32]byte
+	idx[0] = 0x80
+
+	_, err := Select(idx, MaxWeight+1, 10_000_000, 100)
+	if !errors.Is(err, ErrWeightTooLarge) {
+		t.Fatalf("Select(weight=MaxWeight+1) err = %v, want ErrWeightTooLarge", err)
+	}
+}
+
+func TestSelectAtMaxWeightSucceeds(t *testing.T) {
+	var idx [32]byte
+	idx[0] = 0x80
+
+	got, err := Select(idx, MaxWeight, 10_000_000, 100)
+	if err != nil {
+		t.Fatalf("Select(weight=MaxWeight): %v", err)
+	}
+	if got > MaxWeight {
+		t.Fatalf("Select() = %d, must not exceed weight", got)
+	}
+}
+
+// TestSelectIsExactAndReproducible re-derives the expected selection count
+// independently (by summing the exact binomial pmf with big.Rat rather
+// than calling binomialCDFWalk) so Select's result is checked against a
+// second, exact computation rather than merely its own internal walk.
+func TestSelectIsExactAndReproducible(t *testing.T) {
+	weight, totalWeight, expectedSize := uint64(20), uint64(100), 10.0
+
+	for _, b := range []byte{0x00, 0x10, 0x40, 0x80, 0xc0, 0xff} {
+		var idx [32]byte
+		idx[0] = b
+
+		got, err := Select(idx, weight, totalWeight, expectedSize)
+		if err != nil {
+			t.Fatalf("Select(idx[0]=%#x): %v", b, err)
+		}
+
+		want := exactBinomialSelect(weight, expectedSize/float64(totalWeight), ratio(idx))
+		if got != want {
+			t.Fatalf("Select(idx[0]=%#x) = %d, want %d (independently computed)", b, got, want)
+		}
+	}
+}
+
+// exactBinomialSelect independently re-derives the same quantity Select
+// does, but entirely in big.Rat (exact rational arithmetic, no big.Float
+// rounding) and without calling binomialCDFWalk, so it can catch a
+// regression in the production walk rather than just agreeing with itself.
+func exactBinomialSelect(n uint64, pf float64, r *big.Rat) uint64 {
+	p := new(big.Rat).SetFloat64(pf)
+	q := new(big.Rat).Sub(big.NewRat(1, 1), p)
+
+	cdf := new(big.Rat)
+	for j := uint64(0); j <= n; j++ {
+		term := new(big.Rat).SetInt(binomialCoeff(n, j))
+		term.Mul(term, ratPow(p, j))
+		term.Mul(term, ratPow(q, n-j))
+		cdf.Add(cdf, term)
+		if cdf.Cmp(r) > 0 {
+			return j
+		}
+	}
+	return n
+}
+
+func ratPow(x *big.Rat, n uint64) *big.Rat {
+	result := big.NewRat(1, 1)
+	base := new(big.Rat).Set(x)
+	for n > 0 {
+		if n&1 == 1 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+		n >>= 1
+	}
+	return result
+}
+
+func binomialCoeff(n, k uint64) *big.Int {
+	if k > n-k {
+		k = n - k
+	}
+	result := big.NewInt(1)
+	for i := uint64(0); i < k; i++ {
+		result.Mul(result, big.NewInt(int64(n-i)))
+		result.Div(result, big.NewInt(int64(i+1)))
+	}
+	return result
+}